@@ -0,0 +1,95 @@
+// Package zcluster 实现一个最小化的网关(gate)+工作节点(worker)集群模式：
+// 网关接受外部客户端连接，按 msgID 路由到对应的工作节点分组，工作节点
+// 只处理业务逻辑，不直接面向外部客户端。
+package zcluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// NodeType 标识一个集群节点的角色
+type NodeType string
+
+const (
+	NodeTypeGate   NodeType = "gate"
+	NodeTypeWorker NodeType = "worker"
+)
+
+// NodeConfig 描述集群中的一个节点
+type NodeConfig struct {
+	ID   int      `json:"id"`
+	Name string   `json:"name"`
+	Type NodeType `json:"type"`
+	IP   string   `json:"ip"`
+	Port int      `json:"port"`
+}
+
+// RouteEntry 把一个 msgID 映射到一个 worker 分组名
+type RouteEntry struct {
+	MsgID uint32 `json:"msg_id"`
+	Group string `json:"group"`
+}
+
+// Config 是集群的完整配置：参与节点 + 路由表
+type Config struct {
+	Nodes  []NodeConfig `json:"nodes"`
+	Routes []RouteEntry `json:"routes"`
+}
+
+// LoadConfig 从 JSON 文件加载集群配置
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("zcluster: 读取配置文件失败: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("zcluster: 解析配置文件失败: %w", err)
+	}
+	return &cfg, nil
+}
+
+// NodeByID 返回指定 ID 的节点配置，找不到时返回 false
+func (c *Config) NodeByID(id int) (NodeConfig, bool) {
+	for _, n := range c.Nodes {
+		if n.ID == id {
+			return n, true
+		}
+	}
+	return NodeConfig{}, false
+}
+
+// WorkersInGroup 返回某个分组下的全部 worker 节点
+func (c *Config) WorkersInGroup(group string) []NodeConfig {
+	var workers []NodeConfig
+	for _, n := range c.Nodes {
+		if n.Type == NodeTypeWorker && n.Name == group {
+			workers = append(workers, n)
+		}
+	}
+	return workers
+}
+
+// GroupForMsgID 查路由表，返回 msgID 对应的 worker 分组
+func (c *Config) GroupForMsgID(msgID uint32) (string, bool) {
+	for _, r := range c.Routes {
+		if r.MsgID == msgID {
+			return r.Group, true
+		}
+	}
+	return "", false
+}
+
+// msgIDsForGroup 返回路由表里指向某个分组的全部 msgID
+func (c *Config) msgIDsForGroup(group string) []uint32 {
+	var ids []uint32
+	for _, r := range c.Routes {
+		if r.Group == group {
+			ids = append(ids, r.MsgID)
+		}
+	}
+	return ids
+}
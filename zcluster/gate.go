@@ -0,0 +1,225 @@
+package zcluster
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+)
+
+// Gate 是集群的网关节点：接受外部客户端连接，按 msgID 把请求转发给
+// 后端 worker 分组，再把 worker 的响应转发回原始客户端。
+type Gate struct {
+	cfg      *Config
+	self     NodeConfig
+	confPath string
+
+	mu         sync.RWMutex
+	workers    map[string][]ziface.IConnection // group -> 到每个 worker 的长连接
+	rrCounter  map[string]*atomic.Uint64       // group -> 轮询计数器
+	dialedByID map[int]bool                    // worker 节点 ID -> 是否已经拨过号，reload 时跳过
+
+	clientsMu sync.RWMutex
+	clients   map[uint32]ziface.IConnection // 外部客户端 connID -> 连接
+}
+
+// NewGate 构造一个网关节点，nodeID 必须在配置里对应一个 type=gate 的节点
+func NewGate(cfg *Config, confPath string, nodeID int) (*Gate, error) {
+	self, ok := cfg.NodeByID(nodeID)
+	if !ok || self.Type != NodeTypeGate {
+		return nil, fmt.Errorf("zcluster: 节点 %d 不是一个 gate 节点", nodeID)
+	}
+	return &Gate{
+		cfg:        cfg,
+		self:       self,
+		confPath:   confPath,
+		workers:    make(map[string][]ziface.IConnection),
+		rrCounter:  make(map[string]*atomic.Uint64),
+		dialedByID: make(map[int]bool),
+		clients:    make(map[uint32]ziface.IConnection),
+	}, nil
+}
+
+// Start 连接所有 worker，启动对外监听，并阻塞直到服务停止
+func (g *Gate) Start() error {
+	if err := g.dialWorkers(g.cfg); err != nil {
+		return err
+	}
+	g.watchReload()
+
+	s := znet.NewServer()
+	for _, route := range g.cfg.Routes {
+		s.AddRouter(route.MsgID, &forwardRouter{gate: g})
+	}
+
+	s.SetOnConnStart(func(conn ziface.IConnection) {
+		g.clientsMu.Lock()
+		g.clients[conn.GetConnID()] = conn
+		g.clientsMu.Unlock()
+	})
+	s.SetOnConnStop(func(conn ziface.IConnection) {
+		g.clientsMu.Lock()
+		delete(g.clients, conn.GetConnID())
+		g.clientsMu.Unlock()
+	})
+
+	fmt.Printf("[Gate %d] 网关启动在 %s:%d\n", g.self.ID, g.self.IP, g.self.Port)
+	s.Serve()
+	return nil
+}
+
+// dialWorkers 对 cfg 里每个还没连上的 worker 节点建立一条持久的 zinx 连接。
+// 可以反复调用：已经拨过号的节点（按 g.dialedByID 记录）会被跳过，这样
+// reload 时只需要把新出现的 worker 补连上，不会重复拨打已有连接。
+func (g *Gate) dialWorkers(cfg *Config) error {
+	groups := make(map[string]bool)
+	for _, r := range cfg.Routes {
+		groups[r.Group] = true
+	}
+
+	for group := range groups {
+		msgIDs := cfg.msgIDsForGroup(group)
+		for _, w := range cfg.WorkersInGroup(group) {
+			g.mu.Lock()
+			alreadyDialed := g.dialedByID[w.ID]
+			g.mu.Unlock()
+			if alreadyDialed {
+				continue
+			}
+
+			client := znet.NewClient(w.IP, w.Port)
+			if client == nil {
+				return fmt.Errorf("zcluster: 无法连接 worker %s(%d)", w.Name, w.ID)
+			}
+			// worker 的回复仍然带着它所服务的业务 msgID，网关用同一张
+			// 路由表在这条连接上注册回复处理器
+			for _, msgID := range msgIDs {
+				client.AddRouter(msgID, &replyRouter{gate: g})
+			}
+			client.Start()
+
+			g.mu.Lock()
+			g.workers[group] = append(g.workers[group], client.Conn())
+			g.dialedByID[w.ID] = true
+			if _, ok := g.rrCounter[group]; !ok {
+				g.rrCounter[group] = &atomic.Uint64{}
+			}
+			g.mu.Unlock()
+
+			fmt.Printf("[Gate %d] 已连接 worker %s -> %s:%d\n", g.self.ID, w.Name, w.IP, w.Port)
+		}
+	}
+	return nil
+}
+
+// groupForMsgID 在 g.mu 保护下查当前配置的路由表，供 forwardRouter 每次
+// 请求都重新查一遍，这样 reload 换掉的路由表能立刻影响在途流量，而不是
+// 停留在 Start() 时注册路由那一刻的快照上。
+func (g *Gate) groupForMsgID(msgID uint32) (string, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.cfg.GroupForMsgID(msgID)
+}
+
+// pickWorker 按轮询策略从分组里选出一个 worker 连接
+func (g *Gate) pickWorker(group string, connID uint32) (ziface.IConnection, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	conns := g.workers[group]
+	if len(conns) == 0 {
+		return nil, false
+	}
+	// hash on connID 也可以，这里默认轮询；connID 参数保留给未来的哈希策略用
+	idx := g.rrCounter[group].Add(1) % uint64(len(conns))
+	return conns[idx], true
+}
+
+// watchReload 注册 SIGHUP，收到信号后重新加载路由表并补连新出现的 worker。
+// 注意：这只对已经注册过 router 的 msgID 生效——reload 能改变一个已有
+// msgID 转发去哪个分组、以及给分组加新 worker，但没法让配置里全新的 msgID
+// 开始被转发，因为 znet 的路由表是在 Start() 时按 msgID 一次性注册的，
+// 运行中没有"注册一个新 msgID 的 router"这回事。
+func (g *Gate) watchReload() {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	go func() {
+		for range c {
+			newCfg, err := LoadConfig(g.confPath)
+			if err != nil {
+				fmt.Printf("[Gate %d] 重载路由表失败: %s\n", g.self.ID, err)
+				continue
+			}
+
+			g.mu.Lock()
+			g.cfg = newCfg
+			g.mu.Unlock()
+
+			if err := g.dialWorkers(newCfg); err != nil {
+				fmt.Printf("[Gate %d] 重载后连接新 worker 失败: %s\n", g.self.ID, err)
+			}
+
+			fmt.Printf("[Gate %d] 路由表已重载，共 %d 条路由\n", g.self.ID, len(newCfg.Routes))
+		}
+	}()
+}
+
+// forwardRouter 把客户端请求转发给对应分组的 worker。分组通过 gate.cfg
+// 当前的路由表动态查出来，而不是在 AddRouter 时固化成一个字段，这样
+// reload 换掉的路由表能立刻影响这个 msgID 的转发目标。
+type forwardRouter struct {
+	znet.BaseRouter
+	gate *Gate
+}
+
+func (r *forwardRouter) Handle(request ziface.IRequest) {
+	conn := request.GetConnection()
+
+	group, ok := r.gate.groupForMsgID(request.GetMsgID())
+	if !ok {
+		fmt.Printf("[Gate] msgID=%d 没有匹配的分组\n", request.GetMsgID())
+		return
+	}
+
+	worker, ok := r.gate.pickWorker(group, conn.GetConnID())
+	if !ok {
+		fmt.Printf("[Gate] 分组 %s 没有可用的 worker\n", group)
+		return
+	}
+
+	envelope := WrapEnvelope(conn.GetConnID(), request.GetData())
+	if err := worker.SendMsg(request.GetMsgID(), envelope); err != nil {
+		fmt.Printf("[Gate] 转发到 worker 失败: %s\n", err)
+	}
+}
+
+// replyRouter 接收 worker 发回网关的响应，按信封里的 connID 转发给真正的客户端
+type replyRouter struct {
+	znet.BaseRouter
+	gate *Gate
+}
+
+func (r *replyRouter) Handle(request ziface.IRequest) {
+	clientConnID, payload, err := UnwrapEnvelope(request.GetData())
+	if err != nil {
+		fmt.Printf("[Gate] 解析 worker 响应信封失败: %s\n", err)
+		return
+	}
+
+	r.gate.clientsMu.RLock()
+	clientConn, ok := r.gate.clients[clientConnID]
+	r.gate.clientsMu.RUnlock()
+	if !ok {
+		// 客户端可能已经断开，丢弃即可
+		return
+	}
+
+	if err := clientConn.SendMsg(request.GetMsgID(), payload); err != nil {
+		fmt.Printf("[Gate] 回复客户端 %d 失败: %s\n", clientConnID, err)
+	}
+}
@@ -0,0 +1,29 @@
+package zcluster
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// envelopeHeaderLen 是网关转发给 worker 的信封头长度：仅额外携带
+// 产生请求的客户端 connID（4 字节），真正的业务 msgID/dataLen 仍然
+// 由 znet 自己的 dataPack 负责，worker 只需要从 payload 里把 connID
+// 摘出来即可。
+const envelopeHeaderLen = 4
+
+// WrapEnvelope 把客户端的原始 payload 和它的 connID 打包成 worker 能识别的 payload
+func WrapEnvelope(clientConnID uint32, payload []byte) []byte {
+	buf := make([]byte, envelopeHeaderLen+len(payload))
+	binary.BigEndian.PutUint32(buf[0:4], clientConnID)
+	copy(buf[envelopeHeaderLen:], payload)
+	return buf
+}
+
+// UnwrapEnvelope 从 worker 收到的 payload 中取出原始 connID 和业务 payload
+func UnwrapEnvelope(data []byte) (clientConnID uint32, payload []byte, err error) {
+	if len(data) < envelopeHeaderLen {
+		return 0, nil, fmt.Errorf("zcluster: envelope too short: %d bytes", len(data))
+	}
+	clientConnID = binary.BigEndian.Uint32(data[0:4])
+	return clientConnID, data[envelopeHeaderLen:], nil
+}
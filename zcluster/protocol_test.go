@@ -0,0 +1,58 @@
+package zcluster
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrapUnwrapEnvelopeRoundTrip(t *testing.T) {
+	payload := []byte("hello zcluster")
+	wrapped := WrapEnvelope(42, payload)
+
+	connID, got, err := UnwrapEnvelope(wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapEnvelope 返回错误: %s", err)
+	}
+	if connID != 42 {
+		t.Errorf("connID = %d, want 42", connID)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("payload = %q, want %q", got, payload)
+	}
+}
+
+func TestWrapEnvelopeEmptyPayload(t *testing.T) {
+	wrapped := WrapEnvelope(7, nil)
+	connID, payload, err := UnwrapEnvelope(wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapEnvelope 返回错误: %s", err)
+	}
+	if connID != 7 {
+		t.Errorf("connID = %d, want 7", connID)
+	}
+	if len(payload) != 0 {
+		t.Errorf("payload = %v, want 空", payload)
+	}
+}
+
+func TestUnwrapEnvelopeTooShort(t *testing.T) {
+	if _, _, err := UnwrapEnvelope([]byte{1, 2, 3}); err == nil {
+		t.Fatal("UnwrapEnvelope(3字节) 应该报错，因为信封头需要 4 字节")
+	}
+}
+
+func TestWrapEnvelopeMaxConnID(t *testing.T) {
+	payload := []byte("edge case")
+	wrapped := WrapEnvelope(^uint32(0), payload)
+
+	connID, got, err := UnwrapEnvelope(wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapEnvelope 返回错误: %s", err)
+	}
+	if connID != ^uint32(0) {
+		t.Errorf("connID = %d, want %d", connID, ^uint32(0))
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("payload = %q, want %q", got, payload)
+	}
+}
@@ -0,0 +1,94 @@
+package zcluster
+
+import (
+	"fmt"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+)
+
+// Worker 是集群的工作节点：只处理业务逻辑，不直接面向外部客户端。
+// 它收到的每条消息都来自网关，payload 里套了一层 connID 信封。
+type Worker struct {
+	cfg  *Config
+	self NodeConfig
+	s    ziface.IServer
+}
+
+// NewWorker 构造一个 worker 节点，nodeID 必须在配置里对应一个 type=worker 的节点
+func NewWorker(cfg *Config, nodeID int) (*Worker, error) {
+	self, ok := cfg.NodeByID(nodeID)
+	if !ok || self.Type != NodeTypeWorker {
+		return nil, fmt.Errorf("zcluster: 节点 %d 不是一个 worker 节点", nodeID)
+	}
+	return &Worker{cfg: cfg, self: self, s: znet.NewServer()}, nil
+}
+
+// AddRouter 和 znet 的 AddRouter 用法完全一样：业务 router 收到的 request
+// 已经拆掉了网关信封，request.GetConnection().SendMsg 会自动把信封重新
+// 套上去发回网关，和直连客户端时的写法完全没有区别。
+func (w *Worker) AddRouter(msgID uint32, router ziface.IRouter) {
+	w.s.AddRouter(msgID, &workerRouterAdapter{inner: router})
+}
+
+// Start 启动 worker 的监听，接受来自网关的连接
+func (w *Worker) Start() {
+	fmt.Printf("[Worker %d] 启动在 %s:%d\n", w.self.ID, w.self.IP, w.self.Port)
+	w.s.Serve()
+}
+
+// workerRouterAdapter 拆信封，把原始 connID 和 payload 一起交给业务 router，
+// 而不是存到共享连接的属性里——gate↔worker 之间是一条复用的持久连接，上一个
+// 请求的 connID 存在这条连接上，会被下一个转发请求覆盖掉，异步 handler 很容易
+// 读到错的 connID。所以 connID 跟着每个 request 对象走。
+type workerRouterAdapter struct {
+	znet.BaseRouter
+	inner ziface.IRouter
+}
+
+func (a *workerRouterAdapter) Handle(request ziface.IRequest) {
+	clientConnID, payload, err := UnwrapEnvelope(request.GetData())
+	if err != nil {
+		fmt.Println("[Worker] 解析网关信封失败:", err)
+		return
+	}
+	conn := &workerConnection{IConnection: request.GetConnection(), clientConnID: clientConnID}
+	a.inner.Handle(&workerRequest{IRequest: request, conn: conn, data: payload})
+}
+
+// workerConnection 包装网关↔worker 的那条连接，把 SendMsg 重新套上
+// clientConnID 信封，这样业务 router 照常调用 GetConnection().SendMsg
+// 就能透明地经由网关转发给真正的外部客户端，不用记得换成别的方法。
+type workerConnection struct {
+	ziface.IConnection
+	clientConnID uint32
+}
+
+func (c *workerConnection) SendMsg(msgID uint32, data []byte) error {
+	return c.IConnection.SendMsg(msgID, WrapEnvelope(c.clientConnID, data))
+}
+
+func (c *workerConnection) SendBuffMsg(msgID uint32, data []byte) error {
+	return c.IConnection.SendBuffMsg(msgID, WrapEnvelope(c.clientConnID, data))
+}
+
+// 编译期断言：workerConnection 重写的 SendMsg/SendBuffMsg 签名必须和
+// 当前锁定的 zinx 版本里 ziface.IConnection 的签名保持一致，免得下次升级
+// 依赖版本时这两个方法悄悄不再覆盖接口方法、请求又走回了没套信封的那个。
+var _ ziface.IConnection = (*workerConnection)(nil)
+
+// workerRequest 包装一个 ziface.IRequest，替换 GetData() 为拆封后的原始
+// payload，GetConnection() 为套了自动回信封的 workerConnection，其余方法透传
+type workerRequest struct {
+	ziface.IRequest
+	conn *workerConnection
+	data []byte
+}
+
+func (r *workerRequest) GetData() []byte {
+	return r.data
+}
+
+func (r *workerRequest) GetConnection() ziface.IConnection {
+	return r.conn
+}
@@ -0,0 +1,74 @@
+// Package zcodec 在 znet 的 dataPack 帧之上提供一层可插拔的编解码，
+// 让 router 可以直接拿到/返回业务结构体，而不用每次手写 json.Marshal。
+package zcodec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// ICodec 是所有编解码器的统一接口
+type ICodec interface {
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// jsonCodec 基于标准库 encoding/json
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                   { return "json" }
+func (jsonCodec) Marshal(v any) ([]byte, error)   { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(d []byte, v any) error { return json.Unmarshal(d, v) }
+
+// msgpackCodec 基于 github.com/vmihailenco/msgpack
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string                   { return "msgpack" }
+func (msgpackCodec) Marshal(v any) ([]byte, error)   { return msgpack.Marshal(v) }
+func (msgpackCodec) Unmarshal(d []byte, v any) error { return msgpack.Unmarshal(d, v) }
+
+// protobufCodec 基于 google.golang.org/protobuf，要求 v 实现 proto.Message
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string { return "protobuf" }
+
+func (protobufCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("zcodec: %T 没有实现 proto.Message，无法用 protobuf 编码", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (protobufCodec) Unmarshal(d []byte, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("zcodec: %T 没有实现 proto.Message，无法用 protobuf 解码", v)
+	}
+	return proto.Unmarshal(d, msg)
+}
+
+// 内置编解码器的单例
+var (
+	JSON     ICodec = jsonCodec{}
+	MsgPack  ICodec = msgpackCodec{}
+	Protobuf ICodec = protobufCodec{}
+)
+
+// ByName 按名字查找内置编解码器，用于从 -codec 这类命令行参数解析
+func ByName(name string) (ICodec, error) {
+	switch name {
+	case "json":
+		return JSON, nil
+	case "msgpack":
+		return MsgPack, nil
+	case "protobuf":
+		return Protobuf, nil
+	default:
+		return nil, fmt.Errorf("zcodec: 未知的编解码器 %q", name)
+	}
+}
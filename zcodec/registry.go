@@ -0,0 +1,24 @@
+package zcodec
+
+// Registry 持有一个默认编解码器，取代原来进程级的全局变量：同一进程里
+// 想用不同默认编解码器的两个 server（比如集群模式下的 gate 和 worker）
+// 现在各自拿一个 Registry，不会再互相覆盖对方的默认值。
+type Registry struct {
+	defaultCodec ICodec
+}
+
+// NewRegistry 创建一个 registry，defaultCodec 是没有用 WithCodec 显式
+// 指定编解码器时，NewTypedRouter/AddTypedRouter 落回去用的那个
+func NewRegistry(defaultCodec ICodec) *Registry {
+	return &Registry{defaultCodec: defaultCodec}
+}
+
+// SetDefaultCodec 修改这个 registry 的默认编解码器
+func (reg *Registry) SetDefaultCodec(codec ICodec) {
+	reg.defaultCodec = codec
+}
+
+// DefaultCodec 返回这个 registry 当前的默认编解码器
+func (reg *Registry) DefaultCodec() ICodec {
+	return reg.defaultCodec
+}
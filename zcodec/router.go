@@ -0,0 +1,61 @@
+package zcodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+)
+
+// TypedHandler 是解码后的业务处理函数，msg 的动态类型和 AddTypedRouter 的
+// proto 参数一致（一个指向新分配实例的指针）
+type TypedHandler func(request ziface.IRequest, msg any)
+
+// Option 定制单个 typed router 的行为，目前只有编解码器可选
+type Option func(*typedRouter)
+
+// WithCodec 覆盖这个路由使用的编解码器，不传则使用 reg.DefaultCodec()
+func WithCodec(codec ICodec) Option {
+	return func(r *typedRouter) { r.codec = codec }
+}
+
+// NewTypedRouter 构建一个自动解码的 ziface.IRouter，但不注册到任何 server 上，
+// 方便外面（比如 zgraceful）再包一层之后自己决定注册到哪里。默认编解码器
+// 取自 reg，不传 WithCodec 的路由各自跟着所属 registry 走，不会互相影响。
+func (reg *Registry) NewTypedRouter(proto any, handler TypedHandler, opts ...Option) ziface.IRouter {
+	msgType := reflect.TypeOf(proto)
+	if msgType.Kind() == reflect.Ptr {
+		msgType = msgType.Elem()
+	}
+
+	r := &typedRouter{msgType: msgType, handler: handler, codec: reg.DefaultCodec()}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// AddTypedRouter 在 AddRouter 之上加一层自动解码：收到消息后用 reflect.New
+// 生成一个新的 proto 同类型实例，解码进去，再把解码结果交给 handler，
+// 这样业务代码不用在每个 Handle 里手写 Unmarshal。
+func (reg *Registry) AddTypedRouter(s ziface.IServer, msgID uint32, proto any, handler TypedHandler, opts ...Option) {
+	s.AddRouter(msgID, reg.NewTypedRouter(proto, handler, opts...))
+}
+
+// typedRouter 是 AddTypedRouter 背后的真正 ziface.IRouter 实现
+type typedRouter struct {
+	znet.BaseRouter
+	msgType reflect.Type
+	codec   ICodec
+	handler TypedHandler
+}
+
+func (r *typedRouter) Handle(request ziface.IRequest) {
+	msg := reflect.New(r.msgType).Interface()
+	if err := r.codec.Unmarshal(request.GetData(), msg); err != nil {
+		fmt.Printf("[zcodec] msgID=%d 用 %s 解码失败: %s\n", request.GetMsgID(), r.codec.Name(), err)
+		return
+	}
+	r.handler(request, msg)
+}
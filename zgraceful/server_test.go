@@ -0,0 +1,77 @@
+package zgraceful
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/aceld/zinx/ziface"
+)
+
+// blockingRouter 在 Handle 里先通知 started，再卡在 release 上，方便测试
+// 控制"同时有几个请求在途"这个时间窗口
+type blockingRouter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (blockingRouter) PreHandle(ziface.IRequest)  {}
+func (blockingRouter) PostHandle(ziface.IRequest) {}
+func (r blockingRouter) Handle(ziface.IRequest) {
+	r.started <- struct{}{}
+	<-r.release
+}
+
+type noopRouter struct{}
+
+func (noopRouter) PreHandle(ziface.IRequest)  {}
+func (noopRouter) PostHandle(ziface.IRequest) {}
+func (noopRouter) Handle(ziface.IRequest)     {}
+
+func TestTrackingRouterTracksPeakInFlight(t *testing.T) {
+	const concurrency = 5
+	br := blockingRouter{started: make(chan struct{}), release: make(chan struct{})}
+	s := &Server{}
+	tr := &trackingRouter{inner: br, server: s}
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tr.Handle(nil)
+		}()
+	}
+
+	for i := 0; i < concurrency; i++ {
+		<-br.started
+	}
+
+	if got := s.peakInFlight.Load(); got != concurrency {
+		t.Errorf("peakInFlight = %d, want %d", got, concurrency)
+	}
+	if got := s.inFlight.Load(); got != concurrency {
+		t.Errorf("inFlight = %d, want %d", got, concurrency)
+	}
+
+	close(br.release)
+	wg.Wait()
+
+	if got := s.inFlight.Load(); got != 0 {
+		t.Errorf("inFlight 排干后 = %d, want 0", got)
+	}
+	if got := s.peakInFlight.Load(); got != concurrency {
+		t.Errorf("peakInFlight 应该保留历史最高值，got %d want %d", got, concurrency)
+	}
+}
+
+func TestTrackingRouterSerialCallsKeepPeakAtOne(t *testing.T) {
+	s := &Server{}
+	tr := &trackingRouter{inner: noopRouter{}, server: s}
+
+	tr.Handle(nil)
+	tr.Handle(nil)
+
+	if got := s.peakInFlight.Load(); got != 1 {
+		t.Errorf("peakInFlight = %d, want 1（串行调用，同一时刻最多1个在途）", got)
+	}
+}
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -12,41 +13,40 @@ import (
 
 	"github.com/aceld/zinx/ziface"
 	"github.com/aceld/zinx/znet"
+	"github.com/zhaowuzu/Zerust/zcodec"
+	"github.com/zhaowuzu/Zerust/zgraceful"
+	"github.com/zhaowuzu/Zerust/zmiddleware"
 )
 
-// 全局计数器
+// 全局计数器，WS/集群模式下没有接入中间件链，仍然手动计数
 var requestCounter atomic.Uint64
 
-// 回显路由
+// 回显路由，实际的计数/日志/限流都交给 zmiddleware 的链去做
 type EchoRouter struct {
 	znet.BaseRouter
 }
 
 // 处理消息
 func (r *EchoRouter) Handle(request ziface.IRequest) {
-	// 增加计数器
-	requestCounter.Add(1)
-	
 	// 直接返回收到的数据
 	request.GetConnection().SendMsg(request.GetMsgID(), request.GetData())
 }
 
-// 启动服务器
-func runServer() {
-	// 创建服务器
-	s := znet.NewServer()
-
-	// 注册路由
-	s.AddRouter(1, &EchoRouter{})
+// wsListenAddr/wsPath 是 WebSocket 模式下的监听地址和 URL 路径
+const (
+	wsListenAddr = "127.0.0.1:8999"
+	wsPath       = "/zinx"
+)
 
-	// 启动统计协程
+// 启动统计协程，count 由调用方提供，TCP 模式下读 rpsCounter，WS 模式下读 requestCounter
+func startStatsReporter(count func() uint64) {
 	go func() {
 		var lastCount uint64 = 0
 		var lastTime = time.Now()
 
 		for {
 			time.Sleep(1 * time.Second)
-			currentCount := requestCounter.Load()
+			currentCount := count()
 			currentTime := time.Now()
 			elapsed := currentTime.Sub(lastTime).Seconds()
 
@@ -57,32 +57,88 @@ func runServer() {
 			lastTime = currentTime
 		}
 	}()
+}
+
+// 启动服务器，proto 为 "tcp" 或 "ws"，codecName 用于 msgID=2 的结构体回显测试
+func runServer(proto, codecName string) {
+	if proto == "ws" {
+		startStatsReporter(requestCounter.Load)
+		fmt.Println("[Server] 按 Ctrl+C 停止服务器...")
+		runWSServer(wsListenAddr, wsPath)
+		return
+	}
+
+	// 创建服务器，用 zgraceful 包一层以支持优雅关闭
+	s := zgraceful.NewServer(znet.NewServer())
+
+	// 中间件链：panic恢复 -> 请求日志 -> 按连接限流 -> RPS计数，最后才是业务 Handle。
+	// chain 是这个 server 自己的中间件链，不是进程全局的，集群模式下同一进程
+	// 跑多个 server 也不会互相覆盖。
+	chain := zmiddleware.NewChain()
+	rpsCounter := zmiddleware.NewRPSCounter()
+	rateLimiter := zmiddleware.NewRateLimiter(200, 1000) // 桶容量200，每秒补充1000个令牌
+	chain.Use(zmiddleware.Recover, zmiddleware.Logging, rateLimiter.Middleware, rpsCounter.Middleware)
+	s.OnConnClose(rateLimiter.Release) // 连接断开时清掉对应的令牌桶，避免 buckets 无限增长
+
+	// 注册路由
+	s.AddRouter(1, chain.BuildChainRouter(&EchoRouter{}))
+
+	codec, err := resolveEchoCodec(codecName)
+	if err != nil {
+		fmt.Println("[Server]", err)
+		codec = zcodec.JSON
+	}
+	codecRegistry := zcodec.NewRegistry(codec)
+	addCodecEchoRouter(s, codecRegistry, codec)
+
+	// 服务下线前先把统计协程最后一次输出冲出去
+	s.PreShutdownHook(func() {
+		fmt.Printf("[Stats] 关闭前总请求数: %d\n", rpsCounter.Count())
+		fmt.Println("[Server] 即将停止接收新连接，开始排干在途请求...")
+	})
+	// 给还在线的连接发一条下线通知，msgID=99 约定为"server-going-away"
+	s.OnShutdownMsg(99, []byte("server is shutting down"))
+
+	// 启动统计协程
+	startStatsReporter(rpsCounter.Count)
 
 	// 启动服务器
 	fmt.Println("[Server] 基准测试服务器启动在 127.0.0.1:8999")
 	fmt.Println("[Server] 按 Ctrl+C 停止服务器...")
 
-	// 设置优雅关闭
+	// 设置优雅关闭：最多等待5秒排干在途请求，之后强制关闭剩余连接
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-c
-		fmt.Println("[Server] 接收到停止信号，正在关闭...")
-		s.Stop()
+		fmt.Println("[Server] 接收到停止信号，正在优雅关闭...")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.Shutdown(ctx); err != nil {
+			fmt.Println("[Server] 优雅关闭未完全排干:", err)
+		}
+		fmt.Printf("[Server] 关闭完成: 自然断开=%d 强制关闭=%d 峰值在途请求=%d\n",
+			s.LastDrained, s.LastForceClosed, s.PeakInFlight)
+		os.Exit(0)
 	}()
 
 	// 启动服务
 	s.Serve()
 }
 
-// 运行客户端测试
-func runClient(connections, requestsPerConn int) {
-	fmt.Printf("[Client] 开始基准测试: %d 并发连接, 每连接 %d 请求\n", connections, requestsPerConn)
+// 运行客户端测试，proto 为 "tcp" 或 "ws"，codecName 只用于启动前的编解码对比
+func runClient(connections, requestsPerConn int, proto, codecName string) {
+	runCodecComparison(codecName)
+
+	fmt.Printf("[Client] 开始基准测试(proto=%s): %d 并发连接, 每连接 %d 请求\n", proto, connections, requestsPerConn)
 
 	// 统计数据
 	totalRequests := connections * requestsPerConn
 	completedRequests := atomic.Int64{}
 	totalLatency := atomic.Int64{}
+	latencyHist := NewHistogram()
 
 	// 同步屏障，确保所有连接同时开始
 	var wg sync.WaitGroup
@@ -97,6 +153,11 @@ func runClient(connections, requestsPerConn int) {
 		go func(id int) {
 			defer wg.Done()
 
+			if proto == "ws" {
+				runWSClientWorker(id, requestsPerConn, &barrier, &completedRequests, &totalLatency, latencyHist)
+				return
+			}
+
 			// 连接到服务器
 			client := znet.NewClient("127.0.0.1", 8999)
 			if client == nil {
@@ -110,7 +171,7 @@ func runClient(connections, requestsPerConn int) {
 					client.Stop()
 				}
 			}()
-			
+
 			// 等待连接建立
 			time.Sleep(100 * time.Millisecond)
 
@@ -131,14 +192,14 @@ func runClient(connections, requestsPerConn int) {
 				// 发送请求并等待响应
 				// 等待连接建立
 				time.Sleep(100 * time.Millisecond)
-				
+
 				// 获取连接对象
 				conn := client.Conn()
 				if conn == nil {
 					fmt.Printf("[Client %d] 获取连接失败\n", id)
 					continue
 				}
-				
+
 				// 发送消息
 				err := conn.SendMsg(1, payload)
 				if err != nil {
@@ -152,6 +213,7 @@ func runClient(connections, requestsPerConn int) {
 				// 计算延迟（微秒）
 				latency := time.Since(requestStart).Microseconds()
 				totalLatency.Add(latency)
+				latencyHist.Record(uint64(latency))
 
 				// 增加完成请求计数
 				completedRequests.Add(1)
@@ -159,13 +221,22 @@ func runClient(connections, requestsPerConn int) {
 		}(i)
 	}
 
-	// 启动进度报告
+	// 启动进度报告，附带每秒滚动 p99，方便在压测过程中就发现尾延迟抖动
 	go func() {
+		var lastSnapshot []uint64
 		for {
 			time.Sleep(1 * time.Second)
 			completed := completedRequests.Load()
 			progress := float64(completed) / float64(totalRequests) * 100.0
-			fmt.Printf("[Progress] %.2f%% (%d/%d)\n", progress, completed, totalRequests)
+
+			snapshot := latencyHist.Snapshot()
+			rollingP99 := uint64(0)
+			if lastSnapshot != nil {
+				rollingP99 = percentileFromCounts(diffCounts(snapshot, lastSnapshot), 0.99)
+			}
+			lastSnapshot = snapshot
+
+			fmt.Printf("[Progress] %.2f%% (%d/%d) | 当前1秒窗口 p99=%dus\n", progress, completed, totalRequests, rollingP99)
 
 			if completed >= int64(totalRequests) {
 				break
@@ -196,6 +267,9 @@ func runClient(connections, requestsPerConn int) {
 	fmt.Printf("完成请求数: %d\n", completed)
 	fmt.Printf("总耗时: %.2f 秒\n", elapsed.Seconds())
 	fmt.Printf("平均延迟: %.2f 微秒\n", avgLatency)
+	fmt.Printf("p50: %dus  p90: %dus  p99: %dus  p999: %dus  max: %dus\n",
+		latencyHist.Percentile(0.50), latencyHist.Percentile(0.90),
+		latencyHist.Percentile(0.99), latencyHist.Percentile(0.999), latencyHist.Max())
 	fmt.Printf("吞吐量: %.2f 请求/秒\n", float64(completed)/elapsed.Seconds())
 }
 
@@ -0,0 +1,59 @@
+// 集群模式：把基准测试服务器拆成一个 gate 节点和若干 worker 节点，
+// gate 负责接入外部客户端，worker 只跑业务逻辑。
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+	"github.com/zhaowuzu/Zerust/zcluster"
+)
+
+// clusterEchoRouter 是运行在 worker 上的业务逻辑，和 EchoRouter 完全一样：
+// worker.AddRouter 包装过的连接会自动把回复重新套上 connID 信封转发回网关，
+// 这里不需要关心自己跑在 worker 上还是直连客户端。
+type clusterEchoRouter struct {
+	znet.BaseRouter
+}
+
+func (r *clusterEchoRouter) Handle(request ziface.IRequest) {
+	requestCounter.Add(1)
+	if err := request.GetConnection().SendMsg(request.GetMsgID(), request.GetData()); err != nil {
+		fmt.Println("[Worker] 回复失败:", err)
+	}
+}
+
+// runCluster 根据 -mode 启动 gate 或 worker 节点
+func runCluster(mode, configPath string, nodeID int) {
+	cfg, err := zcluster.LoadConfig(configPath)
+	if err != nil {
+		fmt.Println("[Cluster] 加载配置失败:", err)
+		os.Exit(1)
+	}
+
+	switch mode {
+	case "gate":
+		gate, err := zcluster.NewGate(cfg, configPath, nodeID)
+		if err != nil {
+			fmt.Println("[Cluster] 创建 gate 失败:", err)
+			os.Exit(1)
+		}
+		if err := gate.Start(); err != nil {
+			fmt.Println("[Cluster] gate 运行失败:", err)
+			os.Exit(1)
+		}
+	case "worker":
+		worker, err := zcluster.NewWorker(cfg, nodeID)
+		if err != nil {
+			fmt.Println("[Cluster] 创建 worker 失败:", err)
+			os.Exit(1)
+		}
+		worker.AddRouter(1, &clusterEchoRouter{})
+		worker.Start()
+	default:
+		fmt.Printf("[Cluster] 未知的集群角色: %s (应为 gate 或 worker)\n", mode)
+		os.Exit(1)
+	}
+}
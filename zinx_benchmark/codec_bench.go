@@ -0,0 +1,83 @@
+// 编解码对比：同一个结构体负载，分别用 json/msgpack/protobuf 编码，
+// 对比线上字节数和编解码耗时。msgID=2 上还注册了一个真正走网络的
+// typed router，方便和 -codec 选择的编解码器联调。
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/zhaowuzu/Zerust/zcodec"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// BenchPayload 是编解码对比用的结构体负载，同时实现 proto.Message（见
+// codec_bench_pb.go），这样 json/msgpack/protobuf 三种编解码器可以用同一个
+// 结构体公平对比。
+type BenchPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+
+	Seq     int64  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq" msgpack:"seq"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name" msgpack:"name"`
+	Payload []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload" msgpack:"payload"`
+
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+// routerRegistrar 是 ziface.IServer 和 zgraceful.Server 共有的那部分接口，
+// addCodecEchoRouter 只需要注册路由，不关心具体注册到哪一个上面。
+type routerRegistrar interface {
+	AddRouter(msgID uint32, router ziface.IRouter)
+}
+
+// resolveEchoCodec 把 -codec 解析成一个真正能编解码 BenchPayload 的 zcodec.ICodec
+func resolveEchoCodec(name string) (zcodec.ICodec, error) {
+	return zcodec.ByName(name)
+}
+
+// addCodecEchoRouter 在 msgID=2 上注册一个结构体回显 router，使用指定的编解码器。
+// reg 是调用方自己的 zcodec.Registry，不同 server 各用各的，不会共享默认编解码器。
+func addCodecEchoRouter(s routerRegistrar, reg *zcodec.Registry, codec zcodec.ICodec) {
+	s.AddRouter(2, reg.NewTypedRouter(&BenchPayload{}, func(request ziface.IRequest, msg any) {
+		payload := msg.(*BenchPayload)
+		data, err := codec.Marshal(payload)
+		if err != nil {
+			fmt.Println("[Server] 编码响应失败:", err)
+			return
+		}
+		request.GetConnection().SendMsg(2, data)
+	}, zcodec.WithCodec(codec)))
+}
+
+// runCodecComparison 离线（不走网络）对比三种编解码器在同一个负载上的
+// 字节数和耗时，方便挑选 -codec 的值；selected 是当前 -codec 选中的那个，会在输出里标出来
+func runCodecComparison(selected string) {
+	sample := &BenchPayload{Seq: 1, Name: "zinx-benchmark", Payload: make([]byte, 64)}
+	const iterations = 10000
+
+	fmt.Println("\n===== 编解码对比 (结构体负载, 不含网络开销) =====")
+	for _, codec := range []zcodec.ICodec{zcodec.JSON, zcodec.MsgPack, zcodec.Protobuf} {
+		data, err := codec.Marshal(sample)
+		if err != nil {
+			fmt.Printf("%-10s 跳过: %s\n", codec.Name(), err)
+			continue
+		}
+
+		start := time.Now()
+		for i := 0; i < iterations; i++ {
+			encoded, _ := codec.Marshal(sample)
+			var decoded BenchPayload
+			_ = codec.Unmarshal(encoded, &decoded)
+		}
+		elapsed := time.Since(start)
+
+		marker := ""
+		if codec.Name() == selected {
+			marker = " <- 当前选中"
+		}
+		fmt.Printf("%-10s 大小=%d字节  %d次编解码耗时=%s  平均=%.2fus/次%s\n",
+			codec.Name(), len(data), iterations, elapsed, float64(elapsed.Microseconds())/float64(iterations), marker)
+	}
+}
@@ -0,0 +1,123 @@
+// Code generated by hand to mirror protoc-gen-go's output for BenchPayload,
+// since this sandbox has no protoc binary available. Keep it in sync with
+// the message shape in codec_bench.go if fields ever change:
+// message BenchPayload { int64 seq = 1; string name = 2; bytes payload = 3; }
+package main
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+func (x *BenchPayload) Reset() {
+	*x = BenchPayload{}
+	mi := &file_zinx_benchmark_codec_bench_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BenchPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BenchPayload) ProtoMessage() {}
+
+func (x *BenchPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_zinx_benchmark_codec_bench_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BenchPayload.ProtoReflect.Descriptor instead.
+func (*BenchPayload) Descriptor() ([]byte, []int) {
+	return file_zinx_benchmark_codec_bench_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BenchPayload) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *BenchPayload) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BenchPayload) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+var File_zinx_benchmark_codec_bench_proto protoreflect.FileDescriptor
+
+const file_zinx_benchmark_codec_bench_proto_rawDesc = "" +
+	"\n" +
+	" zinx_benchmark/codec_bench.proto\x12\x0ezinx_benchmark\"N\n" +
+	"\fBenchPayload\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayloadB+Z)github.com/zhaowuzu/Zerust/zinx_benchmarkb\x06proto3"
+
+var (
+	file_zinx_benchmark_codec_bench_proto_rawDescOnce sync.Once
+	file_zinx_benchmark_codec_bench_proto_rawDescData []byte
+)
+
+func file_zinx_benchmark_codec_bench_proto_rawDescGZIP() []byte {
+	file_zinx_benchmark_codec_bench_proto_rawDescOnce.Do(func() {
+		file_zinx_benchmark_codec_bench_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_zinx_benchmark_codec_bench_proto_rawDesc), len(file_zinx_benchmark_codec_bench_proto_rawDesc)))
+	})
+	return file_zinx_benchmark_codec_bench_proto_rawDescData
+}
+
+var file_zinx_benchmark_codec_bench_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_zinx_benchmark_codec_bench_proto_goTypes = []any{
+	(*BenchPayload)(nil), // 0: zinx_benchmark.BenchPayload
+}
+var file_zinx_benchmark_codec_bench_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_zinx_benchmark_codec_bench_proto_init() }
+func file_zinx_benchmark_codec_bench_proto_init() {
+	if File_zinx_benchmark_codec_bench_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_zinx_benchmark_codec_bench_proto_rawDesc), len(file_zinx_benchmark_codec_bench_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_zinx_benchmark_codec_bench_proto_goTypes,
+		DependencyIndexes: file_zinx_benchmark_codec_bench_proto_depIdxs,
+		MessageInfos:      file_zinx_benchmark_codec_bench_proto_msgTypes,
+	}.Build()
+	File_zinx_benchmark_codec_bench_proto = out.File
+	file_zinx_benchmark_codec_bench_proto_goTypes = nil
+	file_zinx_benchmark_codec_bench_proto_depIdxs = nil
+}
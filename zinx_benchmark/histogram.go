@@ -0,0 +1,136 @@
+// 延迟直方图：无锁的 log-linear 直方图，用于在高并发下统计 p50/p90/p99/p999/max，
+// 避免像之前那样只统计总和/平均值，看不到尾延迟。
+package main
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+const (
+	// histMinValue/histMaxValue 是可分辨的延迟范围（单位：微秒）
+	histMinValue uint64 = 1
+	histMaxValue uint64 = 60 * 1000 * 1000 // 60s
+
+	// histSubBuckets 是每个数量级内的线性子桶数，128 约等于 0.8% 的精度
+	histSubBuckets = 128
+
+	// histMagnitudes 覆盖 [1, histMaxValue] 所需要的数量级个数
+	histMagnitudes = 27 // bits.Len64(60_000_000) == 26，索引按 magnitude*histSubBuckets 计算，需要 0..26 共 27 档
+)
+
+// Histogram 是一个固定大小的 log-linear 延迟直方图，Record 只做一次
+// bits.Len64 和一次原子自增，热路径上不加锁。
+type Histogram struct {
+	buckets []uint64 // 长度 histMagnitudes*histSubBuckets
+}
+
+// NewHistogram 创建一个空直方图
+func NewHistogram() *Histogram {
+	return &Histogram{buckets: make([]uint64, histMagnitudes*histSubBuckets)}
+}
+
+// bucketIndex 把一个延迟值（微秒）映射到 (magnitude, subBucket) 再拍平成一个索引
+func bucketIndex(v uint64) int {
+	if v < histMinValue {
+		v = histMinValue
+	}
+	if v > histMaxValue {
+		v = histMaxValue
+	}
+
+	magnitude := bits.Len64(v) // v 在 [2^(magnitude-1), 2^magnitude) 内
+	var rangeStart, rangeSize uint64
+	if magnitude <= 1 {
+		rangeStart, rangeSize = 0, 1
+	} else {
+		rangeStart = uint64(1) << (magnitude - 1)
+		rangeSize = rangeStart
+	}
+
+	sub := (v - rangeStart) * histSubBuckets / rangeSize
+	if sub >= histSubBuckets {
+		sub = histSubBuckets - 1
+	}
+	return magnitude*histSubBuckets + int(sub)
+}
+
+// bucketMidpoint 是 bucketIndex 的逆运算，返回某个桶代表的近似延迟值
+func bucketMidpoint(index int) uint64 {
+	magnitude := index / histSubBuckets
+	sub := uint64(index % histSubBuckets)
+
+	if magnitude <= 1 {
+		// 这个数量级的 rangeSize 只有 1（只覆盖 histMinValue 这一个可分辨
+		// 的值），用下面那套 rangeSize/histSubBuckets 的插值公式只会整数
+		// 除法除成 0，直接返回这唯一的代表值。
+		return histMinValue
+	}
+
+	rangeStart := uint64(1) << (magnitude - 1)
+	rangeSize := rangeStart
+
+	return rangeStart + (sub*rangeSize+rangeSize/2)/histSubBuckets
+}
+
+// Record 记录一次延迟采样，v 单位是微秒
+func (h *Histogram) Record(v uint64) {
+	idx := bucketIndex(v)
+	atomic.AddUint64(&h.buckets[idx], 1)
+}
+
+// Snapshot 原子地拷贝出当前的桶计数，用于滚动窗口统计（跟上一次快照做差）
+func (h *Histogram) Snapshot() []uint64 {
+	out := make([]uint64, len(h.buckets))
+	for i := range h.buckets {
+		out[i] = atomic.LoadUint64(&h.buckets[i])
+	}
+	return out
+}
+
+// Percentile 返回整个直方图里 q（0~1）分位的延迟，单位微秒
+func (h *Histogram) Percentile(q float64) uint64 {
+	return percentileFromCounts(h.Snapshot(), q)
+}
+
+// Max 返回观测到的最大延迟所在桶的近似值
+func (h *Histogram) Max() uint64 {
+	counts := h.Snapshot()
+	for i := len(counts) - 1; i >= 0; i-- {
+		if counts[i] > 0 {
+			return bucketMidpoint(i)
+		}
+	}
+	return 0
+}
+
+// percentileFromCounts 在一份桶计数快照上计算分位数，diff 快照（滚动窗口）
+// 和累计快照（整体统计）都可以复用这个函数。
+func percentileFromCounts(counts []uint64, q float64) uint64 {
+	var total uint64
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+
+	target := uint64(q * float64(total))
+	var cumulative uint64
+	for i, c := range counts {
+		cumulative += c
+		if cumulative > target {
+			return bucketMidpoint(i)
+		}
+	}
+	return bucketMidpoint(len(counts) - 1)
+}
+
+// diffCounts 计算两次快照之间的增量，用于滚动窗口的 p99
+func diffCounts(cur, prev []uint64) []uint64 {
+	diff := make([]uint64, len(cur))
+	for i := range cur {
+		diff[i] = cur[i] - prev[i]
+	}
+	return diff
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestBucketIndexMonotonic(t *testing.T) {
+	prev := bucketIndex(histMinValue)
+	for v := histMinValue + 1; v <= 1000; v++ {
+		idx := bucketIndex(v)
+		if idx < prev {
+			t.Fatalf("bucketIndex(%d)=%d 比 bucketIndex(%d)=%d 还小，不是单调递增", v, idx, v-1, prev)
+		}
+		prev = idx
+	}
+}
+
+func TestBucketIndexClamps(t *testing.T) {
+	if got, want := bucketIndex(0), bucketIndex(histMinValue); got != want {
+		t.Errorf("bucketIndex(0) = %d, want 和 bucketIndex(histMinValue) 一样 %d", got, want)
+	}
+	if got, want := bucketIndex(histMaxValue+1000), bucketIndex(histMaxValue); got != want {
+		t.Errorf("bucketIndex(超出上限) = %d, want 和 bucketIndex(histMaxValue) 一样 %d", got, want)
+	}
+}
+
+func TestBucketRoundTrip(t *testing.T) {
+	for _, v := range []uint64{1, 2, 7, 63, 64, 1000, 60_000, 1_000_000, histMaxValue} {
+		idx := bucketIndex(v)
+		mid := bucketMidpoint(idx)
+		// 桶代表的近似值和真实值应该在同一数量级，不要求精确相等
+		if bits.Len64(mid) != bits.Len64(v) {
+			t.Errorf("bucketMidpoint(bucketIndex(%d)) = %d, 不在同一数量级", v, mid)
+		}
+	}
+}
+
+func TestPercentileFromCountsKnownDistribution(t *testing.T) {
+	h := NewHistogram()
+	// 100 个样本：99 个 10us，1 个 10000us，p50/p90 应该落在低延迟桶，
+	// p99 应该能看到那个尾部样本
+	for i := 0; i < 99; i++ {
+		h.Record(10)
+	}
+	h.Record(10_000)
+
+	p50 := h.Percentile(0.50)
+	p99 := h.Percentile(0.99)
+
+	if p50 > 20 {
+		t.Errorf("p50 = %d, 期望接近 10us 的量级", p50)
+	}
+	if p99 < 1000 {
+		t.Errorf("p99 = %d, 期望能看到尾部的 10000us 样本", p99)
+	}
+}
+
+func TestPercentileEmptyHistogram(t *testing.T) {
+	h := NewHistogram()
+	if got := h.Percentile(0.99); got != 0 {
+		t.Errorf("空直方图的 Percentile(0.99) = %d, want 0", got)
+	}
+	if got := h.Max(); got != 0 {
+		t.Errorf("空直方图的 Max() = %d, want 0", got)
+	}
+}
+
+func TestDiffCounts(t *testing.T) {
+	prev := []uint64{1, 2, 3}
+	cur := []uint64{1, 5, 10}
+	diff := diffCounts(cur, prev)
+	want := []uint64{0, 3, 7}
+	for i := range want {
+		if diff[i] != want[i] {
+			t.Errorf("diffCounts[%d] = %d, want %d", i, diff[i], want[i])
+		}
+	}
+}
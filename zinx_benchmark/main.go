@@ -10,11 +10,21 @@ func main() {
 	serverMode := flag.Bool("server", false, "运行服务器模式")
 	connections := flag.Int("connections", 100, "并发连接数")
 	requestsPerConn := flag.Int("requests", 1000, "每连接请求数")
+	proto := flag.String("proto", "tcp", "传输协议: tcp|ws")
+	clusterMode := flag.String("mode", "", "集群角色: gate|worker，留空则是单进程 gate+worker")
+	clusterConfig := flag.String("config", "cluster.json", "集群配置文件路径")
+	nodeID := flag.Int("nodeid", 0, "当前节点在集群配置里的 ID")
+	codecName := flag.String("codec", "json", "结构体负载编解码器: json|msgpack|protobuf")
 	flag.Parse()
 
+	if *clusterMode != "" {
+		runCluster(*clusterMode, *clusterConfig, *nodeID)
+		return
+	}
+
 	if *serverMode {
-		runServer()
+		runServer(*proto, *codecName)
 	} else {
-		runClient(*connections, *requestsPerConn)
+		runClient(*connections, *requestsPerConn, *proto, *codecName)
 	}
 }
\ No newline at end of file
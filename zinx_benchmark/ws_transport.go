@@ -0,0 +1,70 @@
+// WebSocket 传输：复用 zws，注册的还是 EchoRouter 本身，不需要为 WS
+// 模式单独写一份业务逻辑。
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+	"github.com/zhaowuzu/Zerust/zws"
+)
+
+// runWSServer 启动一个 WS 版本的基准测试服务器，注册的 router 本身就是
+// EchoRouter，不用为 WS 模式单独写一份业务逻辑；countingEchoRouter 只是
+// 外面多包一层计数，好和 TCP 模式的统计口径保持一致。
+func runWSServer(addr, path string) {
+	s := zws.NewServer(addr, path)
+	s.AddRouter(1, &countingEchoRouter{})
+
+	if err := s.Serve(); err != nil {
+		fmt.Println("[WS Server] 监听失败:", err)
+	}
+}
+
+// countingEchoRouter 和 EchoRouter 的业务逻辑完全一样，只是额外对接了
+// WS 模式下用的 requestCounter，方便和 TCP 模式的统计口径保持一致。
+type countingEchoRouter struct {
+	znet.BaseRouter
+	inner EchoRouter
+}
+
+func (r *countingEchoRouter) Handle(request ziface.IRequest) {
+	requestCounter.Add(1)
+	r.inner.Handle(request)
+}
+
+// runWSClientWorker 驱动一条 WebSocket 连接完成 requestsPerConn 次回显请求，
+// 统计口径和 runClient 里的 TCP 分支保持一致。
+func runWSClientWorker(id, requestsPerConn int, barrier *sync.WaitGroup, completedRequests *atomic.Int64, totalLatency *atomic.Int64, latencyHist *Histogram) {
+	conn, err := zws.NewClient("127.0.0.1", 8999, wsPath)
+	if err != nil {
+		fmt.Printf("[Client %d] WS连接失败: %s\n", id, err)
+		return
+	}
+	defer conn.Stop()
+
+	barrier.Wait()
+
+	for j := 0; j < requestsPerConn; j++ {
+		payload := make([]byte, 64)
+		for k := range payload {
+			payload[k] = 'A'
+		}
+
+		requestStart := time.Now()
+
+		if err := conn.SendMsg(1, payload); err != nil {
+			fmt.Printf("[Client %d] 请求失败: %s\n", id, err)
+			continue
+		}
+
+		latency := time.Since(requestStart).Microseconds()
+		totalLatency.Add(latency)
+		latencyHist.Record(uint64(latency))
+		completedRequests.Add(1)
+	}
+}
@@ -0,0 +1,58 @@
+package zmiddleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllowConsumesToken(t *testing.T) {
+	b := &tokenBucket{tokens: 5, capacity: 5, refillRate: 0, lastRefill: time.Now()}
+	if !b.allow() {
+		t.Fatal("桶里有令牌时 allow 应该返回 true")
+	}
+	if b.tokens != 4 {
+		t.Errorf("tokens = %v, want 4", b.tokens)
+	}
+}
+
+func TestTokenBucketRefillsOverElapsedTime(t *testing.T) {
+	b := &tokenBucket{tokens: 0, capacity: 10, refillRate: 10, lastRefill: time.Now().Add(-time.Second)}
+	if !b.allow() {
+		t.Fatal("过去1秒按每秒10个令牌补充，应该够放行这一次")
+	}
+	if b.tokens != 9 {
+		t.Errorf("tokens = %v, want 9 (补充10个减去消耗的1个)", b.tokens)
+	}
+}
+
+func TestTokenBucketRefillCapsAtCapacity(t *testing.T) {
+	b := &tokenBucket{tokens: 9, capacity: 10, refillRate: 100, lastRefill: time.Now().Add(-time.Second)}
+	b.allow()
+	if b.tokens != 9 {
+		t.Errorf("tokens = %v, want 9 (补充量超过容量应该被clamp到10，再减去消耗的1个)", b.tokens)
+	}
+}
+
+func TestTokenBucketDeniesWhenEmpty(t *testing.T) {
+	b := &tokenBucket{tokens: 0, capacity: 10, refillRate: 0, lastRefill: time.Now()}
+	if b.allow() {
+		t.Fatal("没有令牌也没有时间流逝时 allow 应该返回 false")
+	}
+}
+
+func TestRateLimiterReleaseRemovesBucket(t *testing.T) {
+	rl := NewRateLimiter(5, 5)
+
+	rl.mu.Lock()
+	rl.buckets[1] = &tokenBucket{tokens: 5, capacity: 5, refillRate: 5, lastRefill: time.Now()}
+	rl.mu.Unlock()
+
+	rl.Release(1)
+
+	rl.mu.Lock()
+	_, ok := rl.buckets[1]
+	rl.mu.Unlock()
+	if ok {
+		t.Error("Release 之后对应 connID 的令牌桶应该被删除")
+	}
+}
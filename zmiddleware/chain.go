@@ -0,0 +1,81 @@
+// Package zmiddleware 给 znet 的 router 加一条类似 HTTP 中间件的责任链：
+// 全局中间件 + 每个 msgID 自己的中间件，在 AddRouterWithChain 注册时就
+// 拼好一条闭包链，热路径上不用每次请求都重新组装。
+package zmiddleware
+
+import (
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+)
+
+// Next 是责任链里"往下走一步"的回调
+type Next func(request ziface.IRequest)
+
+// Middleware 包一层逻辑在 next 前后执行，不调用 next 就等于拦截请求
+type Middleware func(request ziface.IRequest, next Next)
+
+// Chain 持有一组全局中间件，取代原来进程级的全局变量：同一进程里想跑
+// 两个 server（比如 zcluster 单进程 gate+worker 的场景）各自拿一个 Chain，
+// 不会共享或互相覆盖对方的中间件链。
+type Chain struct {
+	middlewares []Middleware
+}
+
+// NewChain 创建一个空的中间件链
+func NewChain() *Chain {
+	return &Chain{}
+}
+
+// Use 给这个 Chain 注册全局中间件，顺序即执行顺序，对这个 Chain 建出来的
+// 所有路由生效
+func (c *Chain) Use(mw ...Middleware) {
+	c.middlewares = append(c.middlewares, mw...)
+}
+
+// buildChain 把一组中间件和最终处理函数拼成一个 Next，在注册时调用一次，
+// 避免每个请求都重新构建闭包链。
+func buildChain(mws []Middleware, final Next) Next {
+	next := final
+	for i := len(mws) - 1; i >= 0; i-- {
+		mw := mws[i]
+		prevNext := next
+		next = func(request ziface.IRequest) {
+			mw(request, prevNext)
+		}
+	}
+	return next
+}
+
+// chainRouter 是 AddRouterWithChain 背后真正注册给 znet 的 router，
+// Handle 只是调用在注册时就拼好的 chain。
+type chainRouter struct {
+	znet.BaseRouter
+	chain Next
+}
+
+func (r *chainRouter) Handle(request ziface.IRequest) {
+	r.chain(request)
+}
+
+// BuildChainRouter 把 c 的全局中间件 + router 自己的中间件 + router.Handle
+// 拼成一个新的 ziface.IRouter，但不注册到任何 server 上，方便外面再包一层
+// （比如 zgraceful 的在途请求计数）之后自己决定注册到哪里。
+func (c *Chain) BuildChainRouter(router ziface.IRouter, mw ...Middleware) ziface.IRouter {
+	all := make([]Middleware, 0, len(c.middlewares)+len(mw))
+	all = append(all, c.middlewares...)
+	all = append(all, mw...)
+
+	final := Next(func(request ziface.IRequest) {
+		router.PreHandle(request)
+		router.Handle(request)
+		router.PostHandle(request)
+	})
+
+	return &chainRouter{chain: buildChain(all, final)}
+}
+
+// AddRouterWithChain 把 c 的全局中间件 + 这条路由自己的中间件 + router.Handle
+// 拼成一条链，注册到 s 上。等价于 s.AddRouter(msgID, c.BuildChainRouter(router, mw...))。
+func (c *Chain) AddRouterWithChain(s ziface.IServer, msgID uint32, router ziface.IRouter, mw ...Middleware) {
+	s.AddRouter(msgID, c.BuildChainRouter(router, mw...))
+}
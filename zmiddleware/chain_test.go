@@ -0,0 +1,38 @@
+package zmiddleware
+
+import (
+	"testing"
+
+	"github.com/aceld/zinx/ziface"
+)
+
+// callTrackingRouter 记录 PreHandle/Handle/PostHandle 各自被调用的次数，
+// 用来验证 BuildChainRouter 包出来的 router 有没有把这三个钩子都转发给
+// 被包的 router，而不是只转发 Handle。
+type callTrackingRouter struct {
+	pre, handle, post int
+}
+
+func (r *callTrackingRouter) PreHandle(ziface.IRequest)  { r.pre++ }
+func (r *callTrackingRouter) Handle(ziface.IRequest)     { r.handle++ }
+func (r *callTrackingRouter) PostHandle(ziface.IRequest) { r.post++ }
+
+func TestBuildChainRouterForwardsAllThreeHooks(t *testing.T) {
+	inner := &callTrackingRouter{}
+	c := NewChain()
+	chained := c.BuildChainRouter(inner)
+
+	// chainRouter.Handle 是唯一注册到 znet 的方法，真正的 PreHandle/Handle/
+	// PostHandle 三段式分发都发生在它背后拼好的 chain 里面
+	chained.Handle(nil)
+
+	if inner.pre != 1 {
+		t.Errorf("inner.PreHandle 被调用了 %d 次, want 1", inner.pre)
+	}
+	if inner.handle != 1 {
+		t.Errorf("inner.Handle 被调用了 %d 次, want 1", inner.handle)
+	}
+	if inner.post != 1 {
+		t.Errorf("inner.PostHandle 被调用了 %d 次, want 1", inner.post)
+	}
+}
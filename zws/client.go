@@ -0,0 +1,19 @@
+package zws
+
+import (
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// NewClient 拨号一个 WS 客户端连接，返回的 *Connection 实现了
+// ziface.IConnection，用法和 znet.NewClient(...).Conn() 拿到的连接一样，
+// 直接调 SendMsg 即可。
+func NewClient(host string, port int, path string) (*Connection, error) {
+	url := fmt.Sprintf("ws://%s:%d%s", host, port, path)
+	raw, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newConnection(raw), nil
+}
@@ -0,0 +1,126 @@
+package zws
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+)
+
+// connIDSeq 给每条 WS 连接分配一个和 znet 里语义一致的自增 connID
+var connIDSeq atomic.Uint32
+
+// Connection 实现 ziface.IConnection，让 WS 连接可以直接塞进
+// IRequest.GetConnection()，业务 router 调用 SendMsg/GetConnID/
+// SetProperty/IsAlive 的方式和 TCP 连接完全一样。
+type Connection struct {
+	id      uint32
+	raw     *websocket.Conn
+	writeMu sync.Mutex
+	alive   atomic.Bool
+
+	propsMu sync.RWMutex
+	props   map[string]interface{}
+}
+
+// newConnection 给一条已经完成握手的原始 websocket 连接包一层 Connection
+func newConnection(raw *websocket.Conn) *Connection {
+	c := &Connection{
+		id:    connIDSeq.Add(1),
+		raw:   raw,
+		props: make(map[string]interface{}),
+	}
+	c.alive.Store(true)
+	return c
+}
+
+// Start 对 WS 连接是空操作。读循环只在 Server 的 handleUpgrade 里运行，
+// NewClient 返回的连接不读取对端发来的帧
+func (c *Connection) Start() {}
+
+// Stop 关闭底层 WebSocket 连接
+func (c *Connection) Stop() {
+	c.alive.Store(false)
+	_ = c.raw.Close()
+}
+
+// GetConnID 返回这条连接的自增 ID，语义和 znet 的 IConnection.GetConnID 一致
+func (c *Connection) GetConnID() uint32 {
+	return c.id
+}
+
+// GetConnection 透传底层 websocket.Conn 包着的原始 net.Conn，和 znet 的
+// IConnection.GetConnection 语义一致
+func (c *Connection) GetConnection() net.Conn {
+	return c.raw.UnderlyingConn()
+}
+
+// IsAlive 判断这条 WS 连接是否还存活，Stop() 之后会变成 false
+func (c *Connection) IsAlive() bool {
+	return c.alive.Load()
+}
+
+// RemoteAddr 透传底层 net.Conn 的远端地址
+func (c *Connection) RemoteAddr() net.Addr {
+	return c.raw.RemoteAddr()
+}
+
+// LocalAddr 透传底层 net.Conn 的本地地址
+func (c *Connection) LocalAddr() net.Addr {
+	return c.raw.LocalAddr()
+}
+
+// SendMsg 把 msgID+data 编码成一帧二进制 WS 消息发出去，和 znet 的
+// IConnection.SendMsg 签名/语义一致。
+func (c *Connection) SendMsg(msgID uint32, data []byte) error {
+	frame := encodeFrame(msgID, data)
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.raw.WriteMessage(websocket.BinaryMessage, frame)
+}
+
+// SendBuffMsg 在 WS 这里和 SendMsg 没有区别，WriteMessage 本身就是成帧发送
+func (c *Connection) SendBuffMsg(msgID uint32, data []byte) error {
+	return c.SendMsg(msgID, data)
+}
+
+// SetProperty/GetProperty/RemoveProperty 和 znet 的 IConnection 用法一致，
+// 每条连接各自独立，不存在跨连接共享导致的并发问题。
+func (c *Connection) SetProperty(key string, value interface{}) {
+	c.propsMu.Lock()
+	defer c.propsMu.Unlock()
+	c.props[key] = value
+}
+
+func (c *Connection) GetProperty(key string) (interface{}, error) {
+	c.propsMu.RLock()
+	defer c.propsMu.RUnlock()
+	v, ok := c.props[key]
+	if !ok {
+		return nil, fmt.Errorf("zws: property %q not found", key)
+	}
+	return v, nil
+}
+
+func (c *Connection) RemoveProperty(key string) {
+	c.propsMu.Lock()
+	defer c.propsMu.Unlock()
+	delete(c.props, key)
+}
+
+// Context 目前没有接入取消/超时链路，先返回 Background 占位
+func (c *Connection) Context() context.Context {
+	return context.Background()
+}
+
+// readFrame 阻塞读取下一帧并拆出 msgID/data，Server 的读循环在用
+func (c *Connection) readFrame() (msgID uint32, data []byte, err error) {
+	_, frame, err := c.raw.ReadMessage()
+	if err != nil {
+		return 0, nil, err
+	}
+	return decodeFrame(frame)
+}
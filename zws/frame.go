@@ -0,0 +1,49 @@
+// Package zws 是 znet 的 WebSocket 版本：复用 zinx 自己的 zpack dataPack
+// 去编码 msgID+data，再把打包出来的字节整体塞进一个二进制 WebSocket 帧里，
+// 消息收到后照样走 ziface.IRouter 的 PreHandle/Handle/PostHandle 三段式
+// 分发，所以 EchoRouter 这类已经写好的业务 router 不用改一行代码就能在 WS
+// 连接上跑起来。
+package zws
+
+import (
+	"fmt"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/zpack"
+)
+
+// frameHeaderLen 和 zpack 默认 dataPack 的头长度保持一致
+const frameHeaderLen = 8
+
+// dataPack 直接复用 zpack 的默认实现（znet server/client 内部也是用它），
+// 保证 WS 帧头和 TCP 连接上吐出来的包头逐字节一致，不再自己维护一份容易
+// 和它跑偏的编码逻辑。
+var dataPack ziface.IDataPack = zpack.Factory().NewPack(ziface.ZinxDataPack)
+
+// encodeFrame 把 msgID+data 打包成一个 WS 二进制帧的 payload
+func encodeFrame(msgID uint32, data []byte) []byte {
+	frame, err := dataPack.Pack(zpack.NewMsgPackage(msgID, data))
+	if err != nil {
+		// zpack 的 DataPack.Pack 只在 binary.Write 失败时才会出错，正常的
+		// uint32 长度数据走不到这里
+		panic(fmt.Sprintf("zws: pack frame failed: %s", err))
+	}
+	return frame
+}
+
+// decodeFrame 从一个 WS 二进制帧里拆出 msgID 和 data。zpack 的 dataPack.Unpack
+// 只认 8 字节的头，body 需要调用方自己从剩余字节里切出来，这里把这两步接起来。
+func decodeFrame(frame []byte) (msgID uint32, data []byte, err error) {
+	if len(frame) < frameHeaderLen {
+		return 0, nil, fmt.Errorf("zws: frame too short: %d bytes", len(frame))
+	}
+	msg, err := dataPack.Unpack(frame[:frameHeaderLen])
+	if err != nil {
+		return 0, nil, fmt.Errorf("zws: %w", err)
+	}
+	body := frame[frameHeaderLen:]
+	if uint32(len(body)) != msg.GetDataLen() {
+		return 0, nil, fmt.Errorf("zws: frame dataLen mismatch: header=%d actual=%d", msg.GetDataLen(), len(body))
+	}
+	return msg.GetMsgID(), body, nil
+}
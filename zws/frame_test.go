@@ -0,0 +1,55 @@
+package zws
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aceld/zinx/zpack"
+)
+
+// TestEncodeFrameMatchesZpackDataPack 证明 zws 自己打的帧和 zinx 的默认
+// zpack dataPack 逐字节一致：用同一个 dataPack 去解 zws 的 encodeFrame 输出，
+// 能拿到同样的 msgID/data，这样两边的包头格式才不会悄悄跑偏。
+func TestEncodeFrameMatchesZpackDataPack(t *testing.T) {
+	data := []byte("hello zws")
+	frame := encodeFrame(99, data)
+
+	msg, err := dataPack.Unpack(frame[:frameHeaderLen])
+	if err != nil {
+		t.Fatalf("zpack dataPack.Unpack 头部失败: %s", err)
+	}
+	if msg.GetMsgID() != 99 {
+		t.Errorf("msgID = %d, want 99", msg.GetMsgID())
+	}
+	body := frame[frameHeaderLen:]
+	if !bytes.Equal(body, data) {
+		t.Errorf("body = %q, want %q", body, data)
+	}
+}
+
+// TestDecodeFrameAcceptsZpackPack 反过来证明：zpack 的 dataPack.Pack 打出来
+// 的包，zws.decodeFrame 也能原样解开，保证两个方向都兼容。
+func TestDecodeFrameAcceptsZpackPack(t *testing.T) {
+	data := []byte("hello zpack")
+	packed, err := dataPack.Pack(zpack.NewMsgPackage(7, data))
+	if err != nil {
+		t.Fatalf("zpack dataPack.Pack 失败: %s", err)
+	}
+
+	msgID, got, err := decodeFrame(packed)
+	if err != nil {
+		t.Fatalf("decodeFrame 返回错误: %s", err)
+	}
+	if msgID != 7 {
+		t.Errorf("msgID = %d, want 7", msgID)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("data = %q, want %q", got, data)
+	}
+}
+
+func TestDecodeFrameTooShort(t *testing.T) {
+	if _, _, err := decodeFrame([]byte{1, 2, 3}); err == nil {
+		t.Fatal("decodeFrame(3字节) 应该报错，因为帧头需要 8 字节")
+	}
+}
@@ -0,0 +1,33 @@
+package zws
+
+import "github.com/aceld/zinx/ziface"
+
+// request 实现 ziface.IRequest，包着一条 WS 连接收到的一帧消息
+type request struct {
+	conn  *Connection
+	msgID uint32
+	data  []byte
+}
+
+func (r *request) GetConnection() ziface.IConnection {
+	return r.conn
+}
+
+func (r *request) GetData() []byte {
+	return r.data
+}
+
+func (r *request) GetMsgID() uint32 {
+	return r.msgID
+}
+
+// BindRouter/Call/Abort/Goto 是 ziface.IRequest 里给新版责任链式路由用的
+// 钩子，dispatch 里固定按 PreHandle->Handle->PostHandle 三段式调用，不走
+// 责任链，所以这里不需要真正的实现。
+func (r *request) BindRouter(router ziface.IRouter) {}
+
+func (r *request) Call() {}
+
+func (r *request) Abort() {}
+
+func (r *request) Goto(step ziface.HandleStep) {}
@@ -0,0 +1,83 @@
+package zws
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	// 基准测试场景不需要校验来源
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Server 是 WS 版本的 znet server：同一份 msgID -> router 的注册表，
+// 同一套 PreHandle/Handle/PostHandle 分发流程，只是连接换成了 WebSocket。
+type Server struct {
+	addr string
+	path string
+
+	mu      sync.RWMutex
+	routers map[uint32]ziface.IRouter
+}
+
+// NewServer 创建一个监听 addr、在 path 上接受 WS 升级的服务器
+func NewServer(addr, path string) *Server {
+	return &Server{addr: addr, path: path, routers: make(map[uint32]ziface.IRouter)}
+}
+
+// AddRouter 和 znet.IServer.AddRouter 用法完全一样：一个 msgID 对应一个 router，
+// 已经写好的业务 router（比如 EchoRouter）不用做任何改动就能注册进来。
+func (s *Server) AddRouter(msgID uint32, router ziface.IRouter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routers[msgID] = router
+}
+
+// Serve 启动 HTTP 服务器并在 path 上接受 WS 升级，阻塞直到出错
+func (s *Server) Serve() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(s.path, s.handleUpgrade)
+
+	fmt.Printf("[zws] WS 服务器启动在 ws://%s%s\n", s.addr, s.path)
+	return http.ListenAndServe(s.addr, mux)
+}
+
+func (s *Server) handleUpgrade(w http.ResponseWriter, r *http.Request) {
+	raw, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		fmt.Println("[zws] 升级连接失败:", err)
+		return
+	}
+
+	conn := newConnection(raw)
+	defer conn.Stop()
+
+	for {
+		msgID, data, err := conn.readFrame()
+		if err != nil {
+			return
+		}
+		s.dispatch(conn, msgID, data)
+	}
+}
+
+// dispatch 按 msgID 找到注册的 router，走和 znet 一样的
+// PreHandle -> Handle -> PostHandle 三段式分发。
+func (s *Server) dispatch(conn *Connection, msgID uint32, data []byte) {
+	s.mu.RLock()
+	router, ok := s.routers[msgID]
+	s.mu.RUnlock()
+	if !ok {
+		fmt.Printf("[zws] msgID=%d 没有注册 router，丢弃消息\n", msgID)
+		return
+	}
+
+	req := &request{conn: conn, msgID: msgID, data: data}
+	router.PreHandle(req)
+	router.Handle(req)
+	router.PostHandle(req)
+}